@@ -0,0 +1,90 @@
+// Package agent bundles a system prompt, a tool allow-list, and a provider
+// configuration into a single named object that the CLI can select between.
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	tokenctx "github.com/micro-agent/bob/context"
+)
+
+// Provider describes how to reach the model backing an Agent: which
+// ChatCompletionProvider implementation to use, the OpenAI-compatible base
+// URL (ignored by providers that don't need one, e.g. Anthropic), the model
+// name, sampling settings, and optional credentials.
+type Provider struct {
+	Type        string  `yaml:"type"` // "openai" (default) or "anthropic"
+	BaseURL     string  `yaml:"base_url"`
+	Model       string  `yaml:"model"`
+	Temperature float64 `yaml:"temperature"`
+	APIKey      string  `yaml:"api_key"`
+}
+
+// Agent is a named system-prompt + toolset + provider combination, e.g. a
+// "code-search" agent that only sees the snippet-lookup MCP tools and talks
+// to a local model.
+type Agent struct {
+	Name         string          `yaml:"name"`
+	SystemPrompt string          `yaml:"system_prompt"`
+	AllowedTools []string        `yaml:"allowed_tools"`
+	Provider     Provider        `yaml:"provider"`
+	Context      tokenctx.Budget `yaml:"context"`
+}
+
+// config is the on-disk shape of ~/.config/bob/agents.yaml.
+type config struct {
+	Agents []Agent `yaml:"agents"`
+}
+
+// AllowsTool reports whether the agent's tool allow-list exposes toolName.
+// An empty allow-list means every tool is exposed.
+func (a Agent) AllowsTool(toolName string) bool {
+	if len(a.AllowedTools) == 0 {
+		return true
+	}
+	for _, name := range a.AllowedTools {
+		if name == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigPath returns the default location of the agents config file,
+// ~/.config/bob/agents.yaml.
+func ConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "bob", "agents.yaml"), nil
+}
+
+// Load reads and parses the agents config file at path.
+func Load(path string) ([]Agent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read agents config %s: %w", path, err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse agents config %s: %w", path, err)
+	}
+	return cfg.Agents, nil
+}
+
+// Find returns the agent named name, or an error if no such agent is
+// configured.
+func Find(agents []Agent, name string) (Agent, error) {
+	for _, a := range agents {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return Agent{}, fmt.Errorf("no agent named %q in config", name)
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/micro-agent/bob/policy"
+)
+
+// RunOptions controls the human-in-the-loop confirmation gate around tool
+// calls during Run.
+type RunOptions struct {
+	// AutoApprove skips confirmation entirely (the --yes flag).
+	AutoApprove bool
+	// DryRun skips execution and synthesizes a "not executed" tool result,
+	// without asking for confirmation.
+	DryRun bool
+	// Policy remembers allow/deny decisions across calls and runs. A nil
+	// Policy is treated as an empty, non-persistent store.
+	Policy *policy.Store
+}
+
+// confirmToolCall decides whether a requested tool call should run. It
+// checks the remembered policy first, then --yes/--dry-run, then falls
+// back to an interactive [y/N/always/never] prompt on stdin.
+func confirmToolCall(opts RunOptions, toolName, arguments string) (bool, error) {
+	store := opts.Policy
+	if store == nil {
+		store = &policy.Store{Decision: map[string]policy.Decision{}}
+	}
+
+	switch store.Get(toolName) {
+	case policy.Allow:
+		return true, nil
+	case policy.Deny:
+		return false, nil
+	}
+
+	if opts.AutoApprove || opts.DryRun {
+		return true, nil
+	}
+
+	fmt.Printf("❓ Run tool %q with args %s? [y/N/always/never] ", toolName, prettyJSON(arguments))
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	case "always":
+		return true, store.Set(toolName, policy.Allow)
+	case "never":
+		return false, store.Set(toolName, policy.Deny)
+	default:
+		return false, nil
+	}
+}
+
+// dryRunResult synthesizes the tool result sent back to the model when
+// --dry-run is set, so the conversation can continue without the tool
+// actually having run.
+func dryRunResult(toolName string) string {
+	return fmt.Sprintf(`{"status": "not executed", "reason": "dry run", "tool": %q}`, toolName)
+}
+
+func prettyJSON(raw string) string {
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return raw
+	}
+	pretty, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return raw
+	}
+	return string(pretty)
+}
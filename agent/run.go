@@ -0,0 +1,238 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	tokenctx "github.com/micro-agent/bob/context"
+	"github.com/micro-agent/bob/mcpregistry"
+	"github.com/micro-agent/bob/provider"
+)
+
+// Transcript is the full message history of a conversation turn.
+type Transcript []provider.Message
+
+// pendingToolCall accumulates the streamed fragments of a single tool call.
+type pendingToolCall struct {
+	id        string
+	name      string
+	arguments string
+}
+
+// newProvider builds the ChatCompletionProvider this agent is configured
+// to use. Defaults to the OpenAI-compatible backend, since that's what
+// local deployments (DMR, llama.cpp) speak.
+func (a Agent) newProvider() (provider.ChatCompletionProvider, error) {
+	switch a.Provider.Type {
+	case "", "openai":
+		return provider.NewOpenAIProvider(a.Provider.BaseURL, a.Provider.APIKey), nil
+	case "anthropic":
+		return provider.NewAnthropicProvider(a.Provider.APIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", a.Provider.Type)
+	}
+}
+
+// Run drives one conversation loop against the agent's provider and the MCP
+// servers in registry: it streams the assistant's reply, dispatches any
+// tool calls the model requests to the server that owns them, feeds the
+// results back, and repeats until the model produces a final "stop"
+// message. It returns that final message and the full transcript,
+// including the tool-call turns.
+//
+// history seeds the conversation, e.g. a transcript reloaded from the
+// store when resuming a prior session. Pass nil to start fresh; the
+// agent's system prompt is only added when history is empty.
+func (a Agent) Run(ctx context.Context, registry *mcpregistry.Registry, history Transcript, userMessage string, opts RunOptions) (string, Transcript, error) {
+	chatProvider, err := a.newProvider()
+	if err != nil {
+		return "", nil, fmt.Errorf("build provider for agent %q: %w", a.Name, err)
+	}
+
+	mcpTools, err := registry.ListTools(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("list MCP tools: %w", err)
+	}
+
+	params := provider.Params{
+		Model:       a.Provider.Model,
+		Temperature: a.Provider.Temperature,
+		Tools:       a.allowedTools(mcpTools),
+	}
+
+	messages := append(Transcript{}, history...)
+	if len(messages) == 0 && a.SystemPrompt != "" {
+		messages = append(messages, provider.Message{Role: provider.RoleSystem, Content: a.SystemPrompt})
+	}
+	messages = append(messages, provider.Message{Role: provider.RoleUser, Content: userMessage})
+
+	summarize := func(ctx context.Context, dropped []provider.Message) (string, error) {
+		excerpt := strings.Builder{}
+		for _, m := range dropped {
+			fmt.Fprintf(&excerpt, "%s: %s\n", m.Role, m.Content)
+		}
+		reply, err := chatProvider.CreateChatCompletion(ctx, provider.Params{Model: a.Provider.Model}, []provider.Message{
+			{Role: provider.RoleUser, Content: "Summarize this conversation excerpt in 2-3 sentences:\n\n" + excerpt.String()},
+		})
+		if err != nil {
+			return "", err
+		}
+		return reply.Content, nil
+	}
+
+	for {
+		// Only the copy sent to the model is trimmed; messages keeps the
+		// full transcript that Run returns.
+		trimmed, err := tokenctx.Trim(ctx, a.Context, messages, summarize)
+		if err != nil {
+			return "", messages, fmt.Errorf("trim context: %w", err)
+		}
+
+		deltas, err := chatProvider.CreateChatCompletionStream(ctx, params, trimmed)
+		if err != nil {
+			return "", messages, fmt.Errorf("stream completion: %w", err)
+		}
+
+		content := ""
+		finishReason := ""
+		toolCalls := map[int]*pendingToolCall{}
+		toolCallOrder := []int{}
+
+		for delta := range deltas {
+			if delta.Err != nil {
+				return "", messages, fmt.Errorf("stream completion: %w", delta.Err)
+			}
+			if delta.FinishReason != "" {
+				finishReason = delta.FinishReason
+			}
+			if delta.Content != "" {
+				fmt.Print(delta.Content)
+				content += delta.Content
+			}
+			if delta.ToolCallID != "" || delta.ToolCallName != "" || delta.ToolCallArgs != "" {
+				pending, ok := toolCalls[delta.ToolCallIndex]
+				if !ok {
+					pending = &pendingToolCall{}
+					toolCalls[delta.ToolCallIndex] = pending
+					toolCallOrder = append(toolCallOrder, delta.ToolCallIndex)
+				}
+				if delta.ToolCallID != "" {
+					pending.id = delta.ToolCallID
+				}
+				if delta.ToolCallName != "" {
+					pending.name = delta.ToolCallName
+				}
+				pending.arguments += delta.ToolCallArgs
+			}
+		}
+
+		switch finishReason {
+		case "tool_calls":
+			if len(toolCallOrder) == 0 {
+				return "", messages, fmt.Errorf("finish_reason tool_calls but no tool calls were streamed")
+			}
+
+			assistantMessage := provider.Message{Role: provider.RoleAssistant}
+			for _, index := range toolCallOrder {
+				pending := toolCalls[index]
+				assistantMessage.ToolCalls = append(assistantMessage.ToolCalls, provider.ToolCall{
+					ID:        pending.id,
+					Name:      pending.name,
+					Arguments: pending.arguments,
+				})
+			}
+			messages = append(messages, assistantMessage)
+
+			for _, index := range toolCallOrder {
+				pending := toolCalls[index]
+
+				approved, err := confirmToolCall(opts, pending.name, pending.arguments)
+				if err != nil {
+					return "", messages, fmt.Errorf("confirm tool call %s: %w", pending.name, err)
+				}
+
+				var resultContent string
+				switch {
+				case !approved:
+					fmt.Printf("⛔ Denied function: %s\n", pending.name)
+					resultContent = fmt.Sprintf(`{"status": "denied", "tool": %q}`, pending.name)
+
+				case opts.DryRun:
+					fmt.Printf("🧪 Dry run, skipping: %s with args: %s\n", pending.name, pending.arguments)
+					resultContent = dryRunResult(pending.name)
+
+				default:
+					fmt.Printf("▶️ Executing function: %s with args: %s\n", pending.name, pending.arguments)
+
+					var args map[string]any
+					args, _ = jsonStringToMap(pending.arguments)
+
+					toolResponse, err := registry.CallTool(ctx, pending.name, args)
+					switch {
+					case err != nil:
+						resultContent = fmt.Sprintf(`{"error": "Function execution failed: %s"}`, err)
+					case len(toolResponse.Content) == 0:
+						resultContent = fmt.Sprintf(`{"error": "tool %q returned no content"}`, pending.name)
+					default:
+						if text, ok := toolResponse.Content[0].(mcp.TextContent); ok {
+							resultContent = text.Text
+						} else {
+							resultContent = fmt.Sprintf(`{"error": "tool %q returned an unsupported content type"}`, pending.name)
+						}
+					}
+				}
+
+				messages = append(messages, provider.Message{
+					Role:       provider.RoleTool,
+					Content:    resultContent,
+					ToolCallID: pending.id,
+				})
+				fmt.Println("✅ ResultContent", resultContent)
+			}
+
+		case "stop":
+			messages = append(messages, provider.Message{Role: provider.RoleAssistant, Content: content})
+			return content, messages, nil
+
+		default:
+			return "", messages, fmt.Errorf("unexpected finish_reason: %s", finishReason)
+		}
+	}
+}
+
+// allowedTools converts the namespaced MCP tool list into provider.Tool,
+// filtered down to the ones this agent is allowed to see. The allow-list is
+// matched against the namespaced name (e.g. "snippets__find_rust") so an
+// agent can be scoped to a specific server as well as a specific tool.
+func (a Agent) allowedTools(tools []mcpregistry.Tool) []provider.Tool {
+	var out []provider.Tool
+	for _, tool := range tools {
+		if !a.AllowsTool(tool.NamespacedName) {
+			continue
+		}
+		out = append(out, provider.Tool{
+			Name:        tool.NamespacedName,
+			Description: tool.Description,
+			Parameters: map[string]any{
+				"type":       "object",
+				"properties": tool.InputSchema.Properties,
+				"required":   tool.InputSchema.Required,
+			},
+		})
+	}
+	return out
+}
+
+// jsonStringToMap converts a JSON string to a Go map. Used to parse
+// function arguments from AI tool calls.
+func jsonStringToMap(jsonString string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonString), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,231 @@
+// Package context tracks approximate token usage across a conversation and
+// trims the transcript before it overflows a model's context window. It's
+// named for what it manages (the model's context), not Go's context.Context
+// -- importers that need both should alias this import, e.g.
+// `tokenctx "github.com/micro-agent/bob/context"`.
+package context
+
+import (
+	stdcontext "context"
+	"fmt"
+
+	"github.com/micro-agent/bob/provider"
+)
+
+// Strategy selects how Trim makes room when a transcript approaches its
+// token budget.
+type Strategy string
+
+const (
+	// DropOldest discards the oldest non-pinned messages until the
+	// transcript fits, preserving the system prompt and the latest user
+	// turn.
+	DropOldest Strategy = "drop-oldest"
+	// SummarizeOldest replaces the discarded prefix with a single
+	// assistant message summarizing it, generated via a cheap side-call.
+	SummarizeOldest Strategy = "summarize-oldest"
+	// SlidingWindow keeps the system prompt plus the most recent WindowSize
+	// messages, regardless of their token count. The window is rounded up
+	// to whole tool-call rounds, so it can hold a few more messages.
+	SlidingWindow Strategy = "sliding-window"
+)
+
+// Budget configures trimming for one agent.
+type Budget struct {
+	// Limit is the approximate token budget for the trimmed transcript,
+	// excluding the model's own completion. Zero disables trimming.
+	Limit int `yaml:"limit"`
+	// Strategy is which trimming approach to apply once Limit is exceeded.
+	Strategy Strategy `yaml:"strategy"`
+	// WindowSize is the message count kept by SlidingWindow. Ignored by
+	// the other strategies.
+	WindowSize int `yaml:"window_size"`
+}
+
+// Summarizer generates a short summary of a run of messages that's about
+// to be dropped, for use by SummarizeOldest. Agents typically implement
+// this with a cheap side-call to their own provider.
+type Summarizer func(ctx stdcontext.Context, dropped []provider.Message) (string, error)
+
+// EstimateTokens heuristically counts the tokens in s at roughly 4
+// characters per token. Good enough for budgeting against local models
+// that don't expose a real tokenizer.
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+func estimateMessageTokens(m provider.Message) int {
+	total := EstimateTokens(m.Content)
+	for _, tc := range m.ToolCalls {
+		total += EstimateTokens(tc.Name) + EstimateTokens(tc.Arguments)
+	}
+	return total
+}
+
+// Trim applies budget.Strategy to messages once their estimated token count
+// exceeds budget.Limit. A zero Limit returns messages unchanged. The system
+// prompt (if messages[0] is RoleSystem) is always kept. DropOldest and
+// SummarizeOldest also always keep the latest user turn and every message
+// after it, and return a transcript that fits within budget.Limit whenever
+// those kept messages fit by themselves. SlidingWindow keeps a message
+// count instead, whatever its token total.
+func Trim(ctx stdcontext.Context, budget Budget, messages []provider.Message, summarize Summarizer) ([]provider.Message, error) {
+	if budget.Limit <= 0 || len(messages) == 0 {
+		return messages, nil
+	}
+
+	pinnedHead := 0
+	if messages[0].Role == provider.RoleSystem {
+		pinnedHead = 1
+	}
+	lastIndex := len(messages) - 1
+	if lastIndex < pinnedHead {
+		return messages, nil
+	}
+
+	switch budget.Strategy {
+	case SlidingWindow:
+		total := 0
+		for _, m := range messages {
+			total += estimateMessageTokens(m)
+		}
+		if total <= budget.Limit {
+			return messages, nil
+		}
+		return trimSlidingWindow(messages, pinnedHead, budget.WindowSize), nil
+
+	case SummarizeOldest:
+		kept, dropped := trimToBudget(messages, pinnedHead, lastIndex, budget.Limit)
+		if len(dropped) == 0 {
+			return messages, nil
+		}
+		if summarize == nil {
+			return nil, fmt.Errorf("context: summarize-oldest strategy requires a Summarizer")
+		}
+		summary, err := summarize(ctx, dropped)
+		if err != nil {
+			return nil, fmt.Errorf("summarize dropped messages: %w", err)
+		}
+		summaryMessage := provider.Message{Role: provider.RoleAssistant, Content: "Earlier conversation summary: " + summary}
+		out := append([]provider.Message{}, kept[:pinnedHead]...)
+		out = append(out, summaryMessage)
+		out = append(out, kept[pinnedHead:]...)
+		return out, nil
+
+	case DropOldest, "":
+		kept, _ := trimToBudget(messages, pinnedHead, lastIndex, budget.Limit)
+		return kept, nil
+
+	default:
+		return nil, fmt.Errorf("context: unknown trimming strategy %q", budget.Strategy)
+	}
+}
+
+// unit is a run of messages that must be dropped or kept together: either a
+// single message, or an assistant tool_calls message together with the
+// tool-result messages answering it. Splitting a unit would leave a
+// tool-result message with no matching tool_call (or vice versa), which
+// both OpenAI and Anthropic reject outright.
+type unit []provider.Message
+
+func unitTokens(u unit) int {
+	total := 0
+	for _, m := range u {
+		total += estimateMessageTokens(m)
+	}
+	return total
+}
+
+// groupUnits walks messages in order, pairing each assistant tool_calls
+// message with the contiguous run of tool-result messages that follow it.
+func groupUnits(messages []provider.Message) []unit {
+	var units []unit
+	for i := 0; i < len(messages); i++ {
+		m := messages[i]
+		if m.Role == provider.RoleAssistant && len(m.ToolCalls) > 0 {
+			u := unit{m}
+			for i+1 < len(messages) && messages[i+1].Role == provider.RoleTool {
+				i++
+				u = append(u, messages[i])
+			}
+			units = append(units, u)
+			continue
+		}
+		units = append(units, unit{m})
+	}
+	return units
+}
+
+// trimToBudget keeps messages[0:pinnedHead] and the tail from the latest
+// user turn through messages[lastIndex] (just messages[lastIndex] if no
+// user turn follows the pinned head), then keeps the longest contiguous
+// suffix of the units in between that fits under limit, dropping only a
+// contiguous oldest prefix of units. It returns the kept messages in
+// original order and the dropped messages in original order.
+func trimToBudget(messages []provider.Message, pinnedHead, lastIndex, limit int) ([]provider.Message, []provider.Message) {
+	tailStart := lastIndex
+	for tailStart > pinnedHead && messages[tailStart].Role != provider.RoleUser {
+		tailStart--
+	}
+	if messages[tailStart].Role != provider.RoleUser {
+		tailStart = lastIndex
+	}
+
+	total := 0
+	for i := 0; i < pinnedHead; i++ {
+		total += estimateMessageTokens(messages[i])
+	}
+	for i := tailStart; i <= lastIndex; i++ {
+		total += estimateMessageTokens(messages[i])
+	}
+
+	units := groupUnits(messages[pinnedHead:tailStart])
+
+	keepFrom := len(units)
+	for i := len(units) - 1; i >= 0; i-- {
+		cost := unitTokens(units[i])
+		if total+cost > limit {
+			break // stop at the first (oldest-reached) unit that doesn't fit
+		}
+		total += cost
+		keepFrom = i
+	}
+
+	var kept, dropped []provider.Message
+	kept = append(kept, messages[:pinnedHead]...)
+	for i := 0; i < keepFrom; i++ {
+		dropped = append(dropped, units[i]...)
+	}
+	for i := keepFrom; i < len(units); i++ {
+		kept = append(kept, units[i]...)
+	}
+	kept = append(kept, messages[tailStart:lastIndex+1]...)
+	return kept, dropped
+}
+
+// trimSlidingWindow keeps messages[0:pinnedHead] plus the most recent
+// windowSize messages, rounding up to whole units so a tool_calls message
+// is never kept without its tool-result (or vice versa).
+func trimSlidingWindow(messages []provider.Message, pinnedHead, windowSize int) []provider.Message {
+	if windowSize <= 0 {
+		windowSize = 1
+	}
+
+	units := groupUnits(messages[pinnedHead:])
+
+	keepFrom := len(units)
+	count := 0
+	for i := len(units) - 1; i >= 0; i-- {
+		if count >= windowSize {
+			break
+		}
+		count += len(units[i])
+		keepFrom = i
+	}
+
+	out := append([]provider.Message{}, messages[:pinnedHead]...)
+	for i := keepFrom; i < len(units); i++ {
+		out = append(out, units[i]...)
+	}
+	return out
+}
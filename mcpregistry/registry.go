@@ -0,0 +1,182 @@
+// Package mcpregistry turns a list of configured MCP servers into a single
+// host: it connects to every server at startup, lists their tools under a
+// server-prefixed name so identical tool names from different servers don't
+// collide, and routes CallTool back to the server that owns the tool.
+package mcpregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// Separator joins a server label and its tool name, e.g. "snippets__find_rust".
+const Separator = "__"
+
+// Transport identifies how to reach an MCP server.
+type Transport string
+
+const (
+	TransportStreamableHTTP Transport = "streamable-http"
+	TransportSSE            Transport = "sse"
+	TransportStdio          Transport = "stdio"
+)
+
+// ServerConfig describes one MCP server to connect to at startup.
+type ServerConfig struct {
+	Name      string    `yaml:"name"`
+	Transport Transport `yaml:"transport"`
+	URL       string    `yaml:"url"`     // streamable-http, sse
+	Command   string    `yaml:"command"` // stdio
+	Args      []string  `yaml:"args"`    // stdio
+}
+
+// Tool is an MCP tool namespaced by the server it came from.
+type Tool struct {
+	// NamespacedName is what's exposed to the model, e.g. "snippets__find_rust".
+	NamespacedName string
+	Server         string
+	mcp.Tool
+}
+
+// serversConfig is the on-disk shape of ~/.config/bob/servers.yaml.
+type serversConfig struct {
+	Servers []ServerConfig `yaml:"servers"`
+}
+
+// ConfigPath returns the default location of the MCP servers config file,
+// ~/.config/bob/servers.yaml.
+func ConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "bob", "servers.yaml"), nil
+}
+
+// LoadServers reads and parses the MCP servers config file at path.
+func LoadServers(path string) ([]ServerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read servers config %s: %w", path, err)
+	}
+
+	var cfg serversConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse servers config %s: %w", path, err)
+	}
+	return cfg.Servers, nil
+}
+
+// Registry holds one connected client per configured MCP server.
+type Registry struct {
+	clients map[string]*client.Client
+	order   []string
+}
+
+// Connect dials every server in servers and initializes the MCP session on
+// each. It fails fast on the first connection error, closing any clients it
+// already connected.
+func Connect(ctx context.Context, servers []ServerConfig) (*Registry, error) {
+	reg := &Registry{clients: map[string]*client.Client{}}
+
+	for _, server := range servers {
+		c, err := newClient(server)
+		if err != nil {
+			reg.Close()
+			return nil, fmt.Errorf("create MCP client %q: %w", server.Name, err)
+		}
+		if err := c.Start(ctx); err != nil {
+			reg.Close()
+			return nil, fmt.Errorf("start MCP client %q: %w", server.Name, err)
+		}
+
+		initRequest := mcp.InitializeRequest{}
+		initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		initRequest.Params.ClientInfo = mcp.Implementation{
+			Name:    "bob",
+			Version: "0.0.0",
+		}
+		if _, err := c.Initialize(ctx, initRequest); err != nil {
+			c.Close()
+			reg.Close()
+			return nil, fmt.Errorf("initialize MCP client %q: %w", server.Name, err)
+		}
+
+		reg.clients[server.Name] = c
+		reg.order = append(reg.order, server.Name)
+	}
+	return reg, nil
+}
+
+// Close shuts down every connected MCP client, e.g. terminating stdio child
+// processes. It collects and returns every close error, rather than
+// stopping at the first one, so a single misbehaving server doesn't leak
+// the rest.
+func (r *Registry) Close() error {
+	var errs []error
+	for _, name := range r.order {
+		if err := r.clients[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close MCP client %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newClient(server ServerConfig) (*client.Client, error) {
+	switch server.Transport {
+	case "", TransportStreamableHTTP:
+		return client.NewStreamableHttpClient(server.URL)
+	case TransportSSE:
+		return client.NewSSEMCPClient(server.URL)
+	case TransportStdio:
+		return client.NewStdioMCPClient(server.Command, nil, server.Args...)
+	default:
+		return nil, fmt.Errorf("unknown transport %q", server.Transport)
+	}
+}
+
+// ListTools lists the tools from every connected server, namespaced by
+// server label so the model sees the union with no name collisions.
+func (r *Registry) ListTools(ctx context.Context) ([]Tool, error) {
+	var all []Tool
+	for _, name := range r.order {
+		result, err := r.clients[name].ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("list tools from %q: %w", name, err)
+		}
+		for _, tool := range result.Tools {
+			all = append(all, Tool{
+				NamespacedName: name + Separator + tool.Name,
+				Server:         name,
+				Tool:           tool,
+			})
+		}
+	}
+	return all, nil
+}
+
+// CallTool dispatches a call to the server that owns namespacedName,
+// stripping the namespace prefix before forwarding the request.
+func (r *Registry) CallTool(ctx context.Context, namespacedName string, args map[string]any) (*mcp.CallToolResult, error) {
+	server, toolName, ok := strings.Cut(namespacedName, Separator)
+	if !ok {
+		return nil, fmt.Errorf("tool name %q is not namespaced", namespacedName)
+	}
+	c, ok := r.clients[server]
+	if !ok {
+		return nil, fmt.Errorf("no MCP server named %q", server)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = toolName
+	request.Params.Arguments = args
+	return c.CallTool(ctx, request)
+}
@@ -0,0 +1,86 @@
+// Package policy persists per-tool confirmation decisions so a user only
+// has to approve or deny a given MCP tool once across runs.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Decision is a remembered answer to "should this tool be allowed to run
+// without asking again?".
+type Decision string
+
+const (
+	Allow  Decision = "allow"
+	Deny   Decision = "deny"
+	Prompt Decision = "prompt" // default: ask every time
+)
+
+// Store is a tool-name -> Decision map backed by a JSON file.
+type Store struct {
+	path     string
+	Decision map[string]Decision `json:"decisions"`
+}
+
+// ConfigPath returns the default location of the tool policy file,
+// ~/.config/bob/tool-policy.json.
+func ConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "bob", "tool-policy.json"), nil
+}
+
+// Load reads the policy file at path, returning an empty Store if it
+// doesn't exist yet.
+func Load(path string) (*Store, error) {
+	store := &Store{path: path, Decision: map[string]Decision{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tool policy %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("parse tool policy %s: %w", path, err)
+	}
+	store.path = path
+	return store, nil
+}
+
+// Get returns the remembered decision for toolName, defaulting to Prompt.
+func (s *Store) Get(toolName string) Decision {
+	if d, ok := s.Decision[toolName]; ok {
+		return d
+	}
+	return Prompt
+}
+
+// Set records decision for toolName and persists the store to disk.
+func (s *Store) Set(toolName string, decision Decision) error {
+	s.Decision[toolName] = decision
+	return s.save()
+}
+
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil // in-memory only, e.g. a Store built without Load
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal tool policy: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("write tool policy %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+// AnthropicProvider talks to the native Anthropic Messages API, translating
+// MCP tool schemas into Anthropic's `tools` block and converting
+// `tool_use`/`tool_result` content blocks back into the internal Message
+// shape.
+type AnthropicProvider struct {
+	client    anthropic.Client
+	maxTokens int64
+}
+
+// NewAnthropicProvider builds a provider authenticated with apiKey.
+func NewAnthropicProvider(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{
+		client:    anthropic.NewClient(option.WithAPIKey(apiKey)),
+		maxTokens: 4096,
+	}
+}
+
+func (p *AnthropicProvider) CreateChatCompletion(ctx context.Context, params Params, messages []Message) (Message, error) {
+	system, anthropicMessages := toAnthropicMessages(messages)
+
+	newParams := anthropic.MessageNewParams{
+		Model:     anthropic.Model(params.Model),
+		MaxTokens: p.maxTokens,
+		Messages:  anthropicMessages,
+		Tools:     toAnthropicTools(params.Tools),
+	}
+	if system != "" {
+		newParams.System = []anthropic.TextBlockParam{{Text: system}}
+	}
+
+	reply, err := p.client.Messages.New(ctx, newParams)
+	if err != nil {
+		return Message{}, fmt.Errorf("anthropic chat completion: %w", err)
+	}
+
+	return fromAnthropicContent(reply.Content), nil
+}
+
+// CreateChatCompletionStream doesn't speak Anthropic's SSE format yet, so it
+// synthesizes a single-chunk stream from the blocking CreateChatCompletion
+// call: one content delta, one delta per tool call, then a final delta
+// carrying FinishReason. This keeps the provider usable through Agent.Run
+// (which only calls the streaming method) until real SSE support lands.
+func (p *AnthropicProvider) CreateChatCompletionStream(ctx context.Context, params Params, messages []Message) (<-chan StreamDelta, error) {
+	out := make(chan StreamDelta)
+
+	go func() {
+		defer close(out)
+
+		msg, err := p.CreateChatCompletion(ctx, params, messages)
+		if err != nil {
+			out <- StreamDelta{Err: err}
+			return
+		}
+
+		if msg.Content != "" {
+			out <- StreamDelta{Content: msg.Content}
+		}
+		for i, tc := range msg.ToolCalls {
+			out <- StreamDelta{
+				ToolCallIndex: i,
+				ToolCallID:    tc.ID,
+				ToolCallName:  tc.Name,
+				ToolCallArgs:  tc.Arguments,
+			}
+		}
+
+		finishReason := "stop"
+		if len(msg.ToolCalls) > 0 {
+			finishReason = "tool_calls"
+		}
+		out <- StreamDelta{FinishReason: finishReason}
+	}()
+
+	return out, nil
+}
+
+func toAnthropicTools(tools []Tool) []anthropic.ToolUnionParam {
+	out := make([]anthropic.ToolUnionParam, len(tools))
+	for i, t := range tools {
+		out[i] = anthropic.ToolUnionParamOfTool(anthropic.ToolInputSchemaParam{
+			Properties: t.Parameters["properties"],
+			Required:   toStringSlice(t.Parameters["required"]),
+		}, t.Name)
+		out[i].OfTool.Description = anthropic.String(t.Description)
+	}
+	return out
+}
+
+func toStringSlice(v any) []string {
+	raw, ok := v.([]string)
+	if ok {
+		return raw
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// toAnthropicMessages splits the provider-agnostic transcript into a system
+// prompt string and the Anthropic message list, converting tool calls and
+// tool results into `tool_use`/`tool_result` content blocks.
+func toAnthropicMessages(messages []Message) (string, []anthropic.MessageParam) {
+	system := ""
+	out := make([]anthropic.MessageParam, 0, len(messages))
+
+	for _, m := range messages {
+		switch m.Role {
+		case RoleSystem:
+			system = m.Content
+
+		case RoleUser:
+			out = append(out, anthropic.NewUserMessage(anthropic.NewTextBlock(m.Content)))
+
+		case RoleAssistant:
+			if len(m.ToolCalls) == 0 {
+				out = append(out, anthropic.NewAssistantMessage(anthropic.NewTextBlock(m.Content)))
+				continue
+			}
+			blocks := make([]anthropic.ContentBlockParamUnion, len(m.ToolCalls))
+			for i, tc := range m.ToolCalls {
+				blocks[i] = anthropic.NewToolUseBlock(tc.ID, tc.Arguments, tc.Name)
+			}
+			out = append(out, anthropic.NewAssistantMessage(blocks...))
+
+		case RoleTool:
+			out = append(out, anthropic.NewUserMessage(
+				anthropic.NewToolResultBlock(m.ToolCallID, m.Content, false),
+			))
+		}
+	}
+	return system, out
+}
+
+// fromAnthropicContent converts an assistant reply's content blocks back
+// into the internal Message shape, gathering any tool_use blocks as
+// ToolCalls alongside plain text content.
+func fromAnthropicContent(blocks []anthropic.ContentBlockUnion) Message {
+	msg := Message{Role: RoleAssistant}
+	for _, block := range blocks {
+		switch variant := block.AsAny().(type) {
+		case anthropic.TextBlock:
+			msg.Content += variant.Text
+		case anthropic.ToolUseBlock:
+			msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+				ID:        variant.ID,
+				Name:      variant.Name,
+				Arguments: string(variant.Input),
+			})
+		}
+	}
+	return msg
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"github.com/openai/openai-go/v2/shared"
+	"github.com/openai/openai-go/v2/shared/constant"
+)
+
+// OpenAIProvider talks to any OpenAI-compatible chat completions endpoint,
+// e.g. Docker Model Runner or llama.cpp serving a local GGUF model.
+type OpenAIProvider struct {
+	client openai.Client
+}
+
+// NewOpenAIProvider builds a provider pointed at baseURL, using apiKey if
+// the endpoint requires one (local deployments typically don't).
+func NewOpenAIProvider(baseURL, apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{
+		client: openai.NewClient(
+			option.WithBaseURL(baseURL),
+			option.WithAPIKey(apiKey),
+		),
+	}
+}
+
+func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, params Params, messages []Message) (Message, error) {
+	completion, err := p.client.Chat.Completions.New(ctx, toOpenAIParams(params, messages))
+	if err != nil {
+		return Message{}, fmt.Errorf("openai chat completion: %w", err)
+	}
+	choice := completion.Choices[0]
+
+	msg := Message{Role: RoleAssistant, Content: choice.Message.Content}
+	for _, tc := range choice.Message.ToolCalls {
+		msg.ToolCalls = append(msg.ToolCalls, ToolCall{
+			ID:        tc.ID,
+			Name:      tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+		})
+	}
+	return msg, nil
+}
+
+func (p *OpenAIProvider) CreateChatCompletionStream(ctx context.Context, params Params, messages []Message) (<-chan StreamDelta, error) {
+	stream := p.client.Chat.Completions.NewStreaming(ctx, toOpenAIParams(params, messages))
+	out := make(chan StreamDelta)
+
+	go func() {
+		defer close(out)
+		for stream.Next() {
+			chunk := stream.Current()
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			choice := chunk.Choices[0]
+
+			delta := StreamDelta{Content: choice.Delta.Content}
+			for _, tc := range choice.Delta.ToolCalls {
+				out <- StreamDelta{
+					ToolCallIndex: int(tc.Index),
+					ToolCallID:    tc.ID,
+					ToolCallName:  tc.Function.Name,
+					ToolCallArgs:  tc.Function.Arguments,
+				}
+			}
+			if choice.Delta.Content != "" || choice.FinishReason != "" {
+				delta.FinishReason = choice.FinishReason
+				out <- delta
+			}
+		}
+		// stream.Err() is only meaningful once Next() has returned false;
+		// checking it any earlier races the goroutine that's still
+		// consuming the stream.
+		if err := stream.Err(); err != nil {
+			out <- StreamDelta{Err: fmt.Errorf("openai stream: %w", err)}
+		}
+	}()
+	return out, nil
+}
+
+func toOpenAIParams(params Params, messages []Message) openai.ChatCompletionNewParams {
+	oaiMessages := make([]openai.ChatCompletionMessageParamUnion, 0, len(messages))
+	for _, m := range messages {
+		oaiMessages = append(oaiMessages, toOpenAIMessage(m))
+	}
+
+	tools := make([]openai.ChatCompletionToolUnionParam, len(params.Tools))
+	for i, t := range params.Tools {
+		tools[i] = openai.ChatCompletionFunctionTool(shared.FunctionDefinitionParam{
+			Name:        t.Name,
+			Description: openai.String(t.Description),
+			Parameters:  shared.FunctionParameters(t.Parameters),
+		})
+	}
+
+	return openai.ChatCompletionNewParams{
+		Model:             params.Model,
+		Temperature:       openai.Opt(params.Temperature),
+		Tools:             tools,
+		ParallelToolCalls: openai.Bool(false),
+		Messages:          oaiMessages,
+	}
+}
+
+func toOpenAIMessage(m Message) openai.ChatCompletionMessageParamUnion {
+	switch m.Role {
+	case RoleSystem:
+		return openai.SystemMessage(m.Content)
+	case RoleUser:
+		return openai.UserMessage(m.Content)
+	case RoleTool:
+		return openai.ToolMessage(m.Content, m.ToolCallID)
+	case RoleAssistant:
+		if len(m.ToolCalls) == 0 {
+			return openai.AssistantMessage(m.Content)
+		}
+		toolCallParams := make([]openai.ChatCompletionMessageToolCallUnionParam, len(m.ToolCalls))
+		for i, tc := range m.ToolCalls {
+			toolCallParams[i] = openai.ChatCompletionMessageToolCallUnionParam{
+				OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+					ID:   tc.ID,
+					Type: constant.Function("function"),
+					Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{
+						Name:      tc.Name,
+						Arguments: tc.Arguments,
+					},
+				},
+			}
+		}
+		return openai.ChatCompletionMessageParamUnion{
+			OfAssistant: &openai.ChatCompletionAssistantMessageParam{
+				ToolCalls: toolCallParams,
+			},
+		}
+	default:
+		return openai.UserMessage(m.Content)
+	}
+}
@@ -0,0 +1,76 @@
+// Package provider abstracts the chat-completion backend behind a single
+// interface so the agent loop can talk to an OpenAI-compatible endpoint
+// (DMR, llama.cpp) or the native Anthropic Messages API without caring
+// which one it's wired to.
+package provider
+
+import "context"
+
+// Role identifies who authored a Message.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// ToolCall is a single function invocation requested by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string // raw JSON
+}
+
+// Message is the provider-agnostic shape of one conversation turn. A
+// RoleAssistant message may carry ToolCalls instead of Content; a RoleTool
+// message answers one of those calls via ToolCallID.
+type Message struct {
+	Role       Role
+	Content    string
+	ToolCalls  []ToolCall
+	ToolCallID string
+}
+
+// Tool is an MCP tool translated into the shape every provider's function
+// calling needs: a name, a description, and a JSON-schema parameter object.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// Params configures a single chat-completion call.
+type Params struct {
+	Model       string
+	Temperature float64
+	Tools       []Tool
+}
+
+// StreamDelta is one fragment of a streamed completion. Content deltas
+// carry text as it's generated; tool-call deltas are index-keyed fragments
+// that the caller accumulates until FinishReason is set, mirroring how
+// OpenAI-compatible APIs stream tool calls.
+type StreamDelta struct {
+	Content       string
+	ToolCallIndex int
+	ToolCallID    string
+	ToolCallName  string
+	ToolCallArgs  string
+	FinishReason  string
+	// Err is set on the final delta if the stream ended because of an
+	// error (network, decode, or API error) rather than a normal finish.
+	Err error
+}
+
+// ChatCompletionProvider is the interface every chat backend implements.
+type ChatCompletionProvider interface {
+	// CreateChatCompletion returns the model's full reply in one call.
+	CreateChatCompletion(ctx context.Context, params Params, messages []Message) (Message, error)
+
+	// CreateChatCompletionStream returns a channel of deltas for the reply.
+	// The channel is closed once the final delta (carrying FinishReason)
+	// has been sent or the context is canceled.
+	CreateChatCompletionStream(ctx context.Context, params Params, messages []Message) (<-chan StreamDelta, error)
+}
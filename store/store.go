@@ -0,0 +1,231 @@
+// Package store persists conversation history to a local SQLite database so
+// multi-turn agent sessions can be listed, inspected, and resumed across
+// runs instead of being one-shot.
+package store
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/micro-agent/bob/provider"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS conversations (
+	id         TEXT PRIMARY KEY,
+	agent      TEXT NOT NULL,
+	model      TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	conversation_id   TEXT NOT NULL,
+	role              TEXT NOT NULL,
+	content           TEXT NOT NULL,
+	tool_calls        TEXT NOT NULL DEFAULT '',
+	tool_call_id      TEXT NOT NULL DEFAULT '',
+	model             TEXT NOT NULL DEFAULT '',
+	prompt_tokens     INTEGER NOT NULL DEFAULT 0,
+	completion_tokens INTEGER NOT NULL DEFAULT 0,
+	created_at        DATETIME NOT NULL,
+	FOREIGN KEY (conversation_id) REFERENCES conversations(id)
+);
+`
+
+// Conversation is one saved session's metadata.
+type Conversation struct {
+	ID        string
+	Agent     string
+	Model     string
+	CreatedAt time.Time
+}
+
+// Store wraps the SQLite database holding conversation history.
+type Store struct {
+	db *sql.DB
+}
+
+// ConfigPath returns the default location of the history database,
+// ~/.local/share/bob/history.db.
+func ConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "bob", "history.db"), nil
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// applies the schema.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open history db %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate history db: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// NewConversation inserts a new conversation row and returns its generated
+// ID.
+func (s *Store) NewConversation(agentName, model string) (string, error) {
+	id, err := newConversationID()
+	if err != nil {
+		return "", fmt.Errorf("generate conversation id: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO conversations (id, agent, model, created_at) VALUES (?, ?, ?, ?)`,
+		id, agentName, model, time.Now().UTC(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("insert conversation: %w", err)
+	}
+	return id, nil
+}
+
+// GetConversation returns the metadata row for conversationID.
+func (s *Store) GetConversation(conversationID string) (Conversation, error) {
+	var c Conversation
+	err := s.db.QueryRow(
+		`SELECT id, agent, model, created_at FROM conversations WHERE id = ?`,
+		conversationID,
+	).Scan(&c.ID, &c.Agent, &c.Model, &c.CreatedAt)
+	if err != nil {
+		return Conversation{}, fmt.Errorf("get conversation %s: %w", conversationID, err)
+	}
+	return c, nil
+}
+
+// ListConversations returns every saved conversation, most recent first.
+func (s *Store) ListConversations() ([]Conversation, error) {
+	rows, err := s.db.Query(`SELECT id, agent, model, created_at FROM conversations ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Conversation
+	for rows.Next() {
+		var c Conversation
+		if err := rows.Scan(&c.ID, &c.Agent, &c.Model, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan conversation: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// AppendMessages persists every message in transcript for conversationID,
+// tagging each row with model and an approximate token count. Messages the
+// model generated (assistant replies and the tool calls they request) are
+// counted as completion tokens; messages fed to the model (the user turn
+// and tool results) are counted as prompt tokens.
+func (s *Store) AppendMessages(conversationID, model string, transcript []provider.Message) error {
+	now := time.Now().UTC()
+	for _, msg := range transcript {
+		role := string(msg.Role)
+		toolCallsJSON := ""
+		tokens := approxTokens(msg.Content)
+
+		if len(msg.ToolCalls) > 0 {
+			role = "tool_call"
+			data, err := json.Marshal(msg.ToolCalls)
+			if err != nil {
+				return fmt.Errorf("marshal tool calls: %w", err)
+			}
+			toolCallsJSON = string(data)
+			for _, tc := range msg.ToolCalls {
+				tokens += approxTokens(tc.Name) + approxTokens(tc.Arguments)
+			}
+		} else if msg.Role == provider.RoleTool {
+			role = "tool_result"
+		}
+
+		promptTokens, completionTokens := tokens, 0
+		if msg.Role == provider.RoleAssistant {
+			promptTokens, completionTokens = 0, tokens
+		}
+
+		_, err := s.db.Exec(
+			`INSERT INTO messages (conversation_id, role, content, tool_calls, tool_call_id, model, prompt_tokens, completion_tokens, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			conversationID, role, msg.Content, toolCallsJSON, msg.ToolCallID,
+			model, promptTokens, completionTokens, now,
+		)
+		if err != nil {
+			return fmt.Errorf("insert message: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadTranscript rebuilds the provider.Message slice for conversationID
+// from stored rows, reconstructing ToolCalls from their JSON column.
+func (s *Store) LoadTranscript(conversationID string) ([]provider.Message, error) {
+	rows, err := s.db.Query(
+		`SELECT role, content, tool_calls, tool_call_id FROM messages WHERE conversation_id = ? ORDER BY id ASC`,
+		conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query messages: %w", err)
+	}
+	defer rows.Close()
+
+	var out []provider.Message
+	for rows.Next() {
+		var role, content, toolCallsJSON, toolCallID string
+		if err := rows.Scan(&role, &content, &toolCallsJSON, &toolCallID); err != nil {
+			return nil, fmt.Errorf("scan message: %w", err)
+		}
+
+		switch role {
+		case "tool_call":
+			var toolCalls []provider.ToolCall
+			if err := json.Unmarshal([]byte(toolCallsJSON), &toolCalls); err != nil {
+				return nil, fmt.Errorf("unmarshal tool calls: %w", err)
+			}
+			out = append(out, provider.Message{Role: provider.RoleAssistant, ToolCalls: toolCalls})
+		case "tool_result":
+			out = append(out, provider.Message{Role: provider.RoleTool, Content: content, ToolCallID: toolCallID})
+		default:
+			out = append(out, provider.Message{Role: provider.Role(role), Content: content})
+		}
+	}
+	return out, rows.Err()
+}
+
+// approxTokens heuristically estimates a token count for content when the
+// provider didn't report real usage, at roughly 4 characters per token.
+func approxTokens(content string) int {
+	return (len(content) + 3) / 4
+}
+
+func newConversationID() (string, error) {
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
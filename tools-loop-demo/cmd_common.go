@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/micro-agent/bob/agent"
+	"github.com/micro-agent/bob/mcpregistry"
+	"github.com/micro-agent/bob/policy"
+)
+
+// session bundles everything a conversation turn needs: the selected
+// agent, the connected MCP servers, and the confirmation policy.
+type session struct {
+	agent    agent.Agent
+	registry *mcpregistry.Registry
+	opts     agent.RunOptions
+}
+
+// sessionFlags are the flags shared by every subcommand that actually talks
+// to a model (new, resume, and the no-subcommand default run).
+func sessionFlags(fs *flag.FlagSet) (agentName *string, autoApprove, dryRun *bool) {
+	agentName = fs.String("agent", "default", "name of the agent to run, as defined in agents.yaml")
+	autoApprove = fs.Bool("yes", false, "auto-approve every tool call without prompting")
+	dryRun = fs.Bool("dry-run", false, "skip tool execution and return a synthesized result to the model")
+	return
+}
+
+func newSession(ctx context.Context, agentName string, autoApprove, dryRun bool) (*session, error) {
+	configPath, err := agent.ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	agents, err := agent.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := agent.Find(agents, agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	policyPath, err := policy.ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	toolPolicy, err := policy.Load(policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	serversPath, err := mcpregistry.ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	servers, err := mcpregistry.LoadServers(serversPath)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := mcpregistry.Connect(ctx, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{
+		agent:    selected,
+		registry: registry,
+		opts: agent.RunOptions{
+			AutoApprove: autoApprove,
+			DryRun:      dryRun,
+			Policy:      toolPolicy,
+		},
+	}, nil
+}
+
+// Close shuts down the MCP servers this session connected to, e.g.
+// terminating stdio child processes.
+func (s *session) Close() error {
+	return s.registry.Close()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// runDefault preserves the original one-shot behavior (no subcommand): run
+// the demo question through the selected agent without persisting history.
+// Use "bob new" to start a conversation that's saved and resumable.
+func runDefault(args []string) {
+	fs := flag.NewFlagSet("bob", flag.ExitOnError)
+	agentName, autoApprove, dryRun := sessionFlags(fs)
+	fs.Parse(args)
+
+	ctx := context.Background()
+	sess, err := newSession(ctx, *agentName, *autoApprove, *dryRun)
+	if err != nil {
+		fatal(err)
+	}
+	defer sess.Close()
+
+	userQuestion := strings.TrimSpace(`
+		Find rust snippet about error handling.
+		Find go snippet about structure.
+	`)
+
+	lastAssistantMessage, _, err := sess.agent.Run(ctx, sess.registry, nil, userQuestion, sess.opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Print(strings.Repeat("=", 5), "[Last Assistant Message]", strings.Repeat("=", 51), "\n")
+	fmt.Println(lastAssistantMessage)
+	fmt.Println(strings.Repeat("=", 80))
+}
@@ -0,0 +1,18 @@
+package main
+
+import "fmt"
+
+// runList prints every saved conversation: "bob list".
+func runList(args []string) {
+	db := openStore()
+	defer db.Close()
+
+	conversations, err := db.ListConversations()
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, c := range conversations {
+		fmt.Printf("%s  %-20s %-30s %s\n", c.ID, c.Agent, c.Model, c.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+}
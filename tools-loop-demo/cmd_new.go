@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// runNew starts a fresh, persisted conversation: "bob new [--agent x] <question>".
+func runNew(args []string) {
+	fs := flag.NewFlagSet("bob new", flag.ExitOnError)
+	agentName, autoApprove, dryRun := sessionFlags(fs)
+	fs.Parse(args)
+
+	question := strings.Join(fs.Args(), " ")
+	if question == "" {
+		fatal(fmt.Errorf("usage: bob new [--agent name] <question>"))
+	}
+
+	ctx := context.Background()
+	sess, err := newSession(ctx, *agentName, *autoApprove, *dryRun)
+	if err != nil {
+		fatal(err)
+	}
+	defer sess.Close()
+
+	db := openStore()
+	defer db.Close()
+
+	conversationID, err := db.NewConversation(sess.agent.Name, sess.agent.Provider.Model)
+	if err != nil {
+		fatal(err)
+	}
+
+	lastAssistantMessage, transcript, err := sess.agent.Run(ctx, sess.registry, nil, question, sess.opts)
+	if err != nil {
+		fatal(err)
+	}
+	if err := db.AppendMessages(conversationID, sess.agent.Provider.Model, transcript); err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("💬 Conversation %s\n", conversationID)
+	fmt.Println(lastAssistantMessage)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// runResume continues a saved conversation: "bob resume <id> [--agent x] <question>".
+// When --agent isn't passed explicitly, the agent the conversation was
+// created with (recorded by "bob new") is used instead of the "default"
+// flag default, so resuming doesn't silently switch providers/models.
+func runResume(args []string) {
+	fs := flag.NewFlagSet("bob resume", flag.ExitOnError)
+	agentName, autoApprove, dryRun := sessionFlags(fs)
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fatal(fmt.Errorf("usage: bob resume <id> [--agent name] <question>"))
+	}
+	conversationID := rest[0]
+	// Flag parsing stops at <id>; parse again so flags may follow it.
+	fs.Parse(rest[1:])
+	question := strings.Join(fs.Args(), " ")
+	if question == "" {
+		fatal(fmt.Errorf("usage: bob resume <id> [--agent name] <question>"))
+	}
+
+	agentFlagSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "agent" {
+			agentFlagSet = true
+		}
+	})
+
+	db := openStore()
+	defer db.Close()
+
+	conversation, err := db.GetConversation(conversationID)
+	if err != nil {
+		fatal(err)
+	}
+
+	effectiveAgentName := conversation.Agent
+	if agentFlagSet {
+		effectiveAgentName = *agentName
+	}
+
+	ctx := context.Background()
+	sess, err := newSession(ctx, effectiveAgentName, *autoApprove, *dryRun)
+	if err != nil {
+		fatal(err)
+	}
+	defer sess.Close()
+
+	history, err := db.LoadTranscript(conversationID)
+	if err != nil {
+		fatal(err)
+	}
+
+	lastAssistantMessage, transcript, err := sess.agent.Run(ctx, sess.registry, history, question, sess.opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	// Only the new turns need persisting; everything up to len(history)
+	// was already saved on a prior run.
+	newTurns := transcript[len(history):]
+	if err := db.AppendMessages(conversationID, sess.agent.Provider.Model, newTurns); err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("💬 Conversation %s\n", conversationID)
+	fmt.Println(lastAssistantMessage)
+}
@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+// runShow prints the transcript of a saved conversation: "bob show <id>".
+func runShow(args []string) {
+	if len(args) < 1 {
+		fatal(fmt.Errorf("usage: bob show <id>"))
+	}
+	conversationID := args[0]
+
+	db := openStore()
+	defer db.Close()
+
+	transcript, err := db.LoadTranscript(conversationID)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, msg := range transcript {
+		switch {
+		case len(msg.ToolCalls) > 0:
+			for _, tc := range msg.ToolCalls {
+				fmt.Printf("[tool_call] %s(%s)\n", tc.Name, tc.Arguments)
+			}
+		default:
+			fmt.Printf("[%s] %s\n", msg.Role, msg.Content)
+		}
+	}
+}